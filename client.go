@@ -0,0 +1,176 @@
+package hrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+)
+
+// ClientCodec marshals outgoing request bodies and unmarshals incoming
+// response bodies for Client. The zero value encodes/decodes JSON, mirroring
+// the Codec registry used on the server side so the same wire format works
+// on both ends.
+type ClientCodec struct {
+	Marshal   func(v any) ([]byte, error)
+	Unmarshal func(data []byte, v any) error
+	Mime      string // sent as Content-Type and Accept; defaults to "application/json"
+}
+
+func (c ClientCodec) marshal(v any) ([]byte, error) {
+	if c.Marshal == nil {
+		return json.Marshal(v)
+	}
+	return c.Marshal(v)
+}
+
+func (c ClientCodec) unmarshal(data []byte, v any) error {
+	if c.Unmarshal == nil {
+		return json.Unmarshal(data, v)
+	}
+	return c.Unmarshal(data, v)
+}
+
+func (c ClientCodec) mime() string {
+	if c.Mime == "" {
+		return "application/json"
+	}
+	return c.Mime
+}
+
+// Client calls hrpc endpoints over HTTP, mirroring Manager.Handler in
+// reverse: it encodes a request, issues an HTTP POST, and decodes the
+// response (or error envelope) back into a typed result.
+type Client struct {
+	HTTPClient *http.Client
+	Codec      ClientCodec
+
+	Before func(*http.Request)  // runs on the outgoing request before it is sent
+	After  func(*http.Response) // runs on the response once received, before decoding
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return c.HTTPClient
+}
+
+// do marshals req, POSTs it to url, and unmarshals the response body into
+// resp, or returns an error decoded from the error envelope on non-2xx
+// status.
+func (c *Client) do(ctx context.Context, url string, req, resp any) error {
+	body, err := c.Codec.marshal(req)
+	if err != nil {
+		return fmt.Errorf("hrpc: encode request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("hrpc: build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", c.Codec.mime())
+	httpReq.Header.Set("Accept", c.Codec.mime())
+	if c.Before != nil {
+		c.Before(httpReq)
+	}
+
+	httpResp, err := c.httpClient().Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("hrpc: do request: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	if c.After != nil {
+		c.After(httpResp)
+	}
+
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return fmt.Errorf("hrpc: read response: %w", err)
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		// DefaultErrorEncoder's envelope: {"error": {"code","reason","message","details"}}
+		var body errorResponseBody
+		if err := c.Codec.unmarshal(data, &body); err == nil {
+			if d := body.Error; d.Message != "" || d.Reason != "" || d.Code != 0 {
+				return &Error{
+					Code:    d.Code,
+					Reason:  d.Reason,
+					Message: d.Message,
+					Details: d.Details,
+				}
+			}
+		}
+
+		// fall back for servers using a bare {"error": "message"} ErrorEncoder
+		var flatBody struct {
+			Error string `json:"error"`
+		}
+		if err := c.Codec.unmarshal(data, &flatBody); err == nil && flatBody.Error != "" {
+			return fmt.Errorf("hrpc: %s: %s", httpResp.Status, flatBody.Error)
+		}
+
+		return fmt.Errorf("hrpc: unexpected status %s", httpResp.Status)
+	}
+
+	if resp == nil || len(data) == 0 {
+		return nil
+	}
+	return c.Codec.unmarshal(data, resp)
+}
+
+// Endpoint reflects on fn, a function value of the shape
+// func(context.Context, *Req) (*Resp, error), and returns a new function of
+// the same type that performs the HTTP round trip to url. Callers type
+// assert the result back to their concrete function type:
+//
+//	getUser := c.Endpoint(url, (func(context.Context, *GetUserReq) (*GetUserResp, error))(nil)).
+//		(func(context.Context, *GetUserReq) (*GetUserResp, error))
+//
+// Use NewClient for a type-safe equivalent that avoids the assertion.
+func (c *Client) Endpoint(url string, fn any) any {
+	ft := reflect.TypeOf(fn)
+	if ft == nil || ft.Kind() != reflect.Func {
+		panic("hrpc: fn must be a function")
+	}
+	if ft.NumIn() != 2 || ft.NumOut() != 2 {
+		panic("hrpc: fn must have signature func(context.Context, *Req) (*Resp, error)")
+	}
+	if ft.In(0).String() != strContext {
+		panic("hrpc: fn's first input must be context.Context")
+	}
+	if ft.Out(1).String() != strError {
+		panic("hrpc: fn's second output must be error")
+	}
+
+	respType := ft.Out(0)
+
+	return reflect.MakeFunc(ft, func(args []reflect.Value) []reflect.Value {
+		ctx := args[0].Interface().(context.Context)
+		req := args[1].Interface()
+
+		respPtr := reflect.New(respType.Elem())
+		err := c.do(ctx, url, req, respPtr.Interface())
+		if err != nil {
+			return []reflect.Value{reflect.Zero(respType), reflect.ValueOf(err)}
+		}
+		return []reflect.Value{respPtr, reflect.Zero(ft.Out(1))}
+	}).Interface()
+}
+
+// NewClient returns a type-safe endpoint function that POSTs Req to url
+// using c and decodes the result into Resp.
+func NewClient[Req, Resp any](c *Client, url string) func(ctx context.Context, req *Req) (*Resp, error) {
+	return func(ctx context.Context, req *Req) (*Resp, error) {
+		resp := new(Resp)
+		if err := c.do(ctx, url, req, resp); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+}
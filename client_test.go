@@ -0,0 +1,113 @@
+package hrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type echoReq struct {
+	Name string `json:"name"`
+}
+
+type echoResp struct {
+	Greeting string `json:"greeting"`
+}
+
+func newEchoServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	m := Manager{
+		Decoder: jsonDecoder,
+		Encoder: func(w http.ResponseWriter, r *http.Request, res any) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(res)
+		},
+		// ErrorEncoder is left unset so the server uses DefaultErrorEncoder,
+		// the same envelope Client.do expects to decode.
+	}
+	h := m.Handler(func(ctx context.Context, req *echoReq) (*echoResp, error) {
+		if req.Name == "" {
+			return nil, errNameRequired
+		}
+		return &echoResp{Greeting: "hello " + req.Name}, nil
+	})
+	return httptest.NewServer(h)
+}
+
+var errNameRequired = ErrBadRequest.With("name required")
+
+func TestClientNewClient(t *testing.T) {
+	srv := newEchoServer(t)
+	defer srv.Close()
+
+	c := &Client{}
+	call := NewClient[echoReq, echoResp](c, srv.URL)
+
+	resp, err := call(context.Background(), &echoReq{Name: "gopher"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Greeting != "hello gopher" {
+		t.Fatalf("unexpected greeting: %q", resp.Greeting)
+	}
+
+	_, err = call(context.Background(), &echoReq{})
+	if err == nil {
+		t.Fatalf("expected error for empty name")
+	}
+	var herr *Error
+	if !errors.As(err, &herr) {
+		t.Fatalf("expected a decoded *Error, got %T: %v", err, err)
+	}
+	if herr.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected code: %d", herr.Code)
+	}
+	if herr.Reason != "bad_request" {
+		t.Fatalf("unexpected reason: %q", herr.Reason)
+	}
+	if herr.Message != "name required" {
+		t.Fatalf("unexpected message: %q", herr.Message)
+	}
+}
+
+func TestClientEndpoint(t *testing.T) {
+	srv := newEchoServer(t)
+	defer srv.Close()
+
+	c := &Client{}
+	call := c.Endpoint(srv.URL, (func(context.Context, *echoReq) (*echoResp, error))(nil)).(func(context.Context, *echoReq) (*echoResp, error))
+
+	resp, err := call(context.Background(), &echoReq{Name: "world"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Greeting != "hello world" {
+		t.Fatalf("unexpected greeting: %q", resp.Greeting)
+	}
+}
+
+func TestClientBeforeAfterHooks(t *testing.T) {
+	srv := newEchoServer(t)
+	defer srv.Close()
+
+	var sawRequest bool
+	var sawResponse bool
+	c := &Client{
+		Before: func(r *http.Request) { sawRequest = true },
+		After:  func(r *http.Response) { sawResponse = true },
+	}
+	call := NewClient[echoReq, echoResp](c, srv.URL)
+
+	if _, err := call(context.Background(), &echoReq{Name: "hook"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !sawRequest {
+		t.Fatalf("Before hook not called")
+	}
+	if !sawResponse {
+		t.Fatalf("After hook not called")
+	}
+}
@@ -0,0 +1,107 @@
+package hrpc
+
+import (
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Codec decodes a request body and encodes a response body for a particular
+// content type. A Codec can be registered under more than one MIME type by
+// listing the extra names in Mimes.
+type Codec struct {
+	Decode func(r *http.Request, dst any) error
+	Encode func(w http.ResponseWriter, r *http.Request, v any)
+	Mimes  []string // additional MIME type aliases for this codec
+}
+
+// Codecs is a registry of Codec keyed by MIME type, e.g. "application/json".
+// When a Manager's Codecs is set, it takes precedence over Decoder/Encoder:
+// the decoder is picked from the request's Content-Type and the encoder is
+// picked from the request's Accept header.
+type Codecs map[string]Codec
+
+// expand returns a lookup map including every alias from c.Mimes.
+func (cs Codecs) expand() map[string]Codec {
+	m := make(map[string]Codec, len(cs))
+	for mt, c := range cs {
+		m[mt] = c
+		for _, alias := range c.Mimes {
+			m[alias] = c
+		}
+	}
+	return m
+}
+
+func parseMime(s string) string {
+	mt, _, err := mime.ParseMediaType(s)
+	if err != nil {
+		return strings.TrimSpace(s)
+	}
+	return mt
+}
+
+type acceptEntry struct {
+	mime string
+	q    float64
+}
+
+// parseAccept parses an Accept header into mime types ordered by quality
+// factor, highest first.
+func parseAccept(header string) []acceptEntry {
+	parts := strings.Split(header, ",")
+	entries := make([]acceptEntry, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		mt, params, err := mime.ParseMediaType(p)
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		entries = append(entries, acceptEntry{mime: mt, q: q})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].q > entries[j].q
+	})
+	return entries
+}
+
+// codecForContentType resolves the decode codec from the request's
+// Content-Type header.
+func codecForContentType(codecs map[string]Codec, r *http.Request) (Codec, bool) {
+	ct := r.Header.Get("Content-Type")
+	if ct == "" {
+		return Codec{}, false
+	}
+	c, ok := codecs[parseMime(ct)]
+	return c, ok
+}
+
+// codecForAccept resolves the encode codec and its MIME type from the
+// request's Accept header, honoring quality factors and falling back to
+// defaultMime when Accept is empty, "*/*", or matches nothing registered.
+func codecForAccept(codecs map[string]Codec, r *http.Request, defaultMime string) (string, Codec, bool) {
+	accept := r.Header.Get("Accept")
+	for _, e := range parseAccept(accept) {
+		if e.mime == "*/*" {
+			break
+		}
+		if c, ok := codecs[e.mime]; ok {
+			return e.mime, c, true
+		}
+	}
+	if c, ok := codecs[defaultMime]; ok {
+		return defaultMime, c, true
+	}
+	return "", Codec{}, false
+}
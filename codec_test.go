@@ -0,0 +1,83 @@
+package hrpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func plainEncode(w http.ResponseWriter, r *http.Request, v any) {
+	w.Write([]byte(v.(string)))
+}
+
+func TestCodecs(t *testing.T) {
+	m := Manager{
+		Codecs: Codecs{
+			"application/json": {
+				Decode: jsonDecoder,
+				Encode: func(w http.ResponseWriter, r *http.Request, v any) {
+					json.NewEncoder(w).Encode(v)
+				},
+			},
+			"text/plain": {
+				Decode: func(r *http.Request, dst any) error { return nil },
+				Encode: plainEncode,
+				Mimes:  []string{"text"},
+			},
+		},
+		DefaultMime: "application/json",
+	}
+
+	h := m.Handler(func(ctx context.Context, req *requestType) (any, error) {
+		return "ok", nil
+	})
+
+	// Accept: text/plain should select the text codec
+	r := httptest.NewRequest(http.MethodPost, "http://localhost", strings.NewReader("{\"data\": 1}"))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Accept", "text/plain")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if ct := w.Header().Get("Content-Type"); ct != "text/plain" {
+		t.Fatalf("expected Content-Type text/plain, got %q", ct)
+	}
+	if body := w.Body.String(); body != "ok" {
+		t.Fatalf("unexpected body: %q", body)
+	}
+
+	// unrecognized Accept falls back to DefaultMime
+	r = httptest.NewRequest(http.MethodPost, "http://localhost", strings.NewReader("{\"data\": 1}"))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Accept", "application/xml")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("expected Content-Type application/json, got %q", ct)
+	}
+
+	// Mimes alias also resolves to the same codec
+	r = httptest.NewRequest(http.MethodPost, "http://localhost", strings.NewReader("{\"data\": 1}"))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Accept", "text")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if ct := w.Header().Get("Content-Type"); ct != "text" {
+		t.Fatalf("expected Content-Type text, got %q", ct)
+	}
+}
+
+func TestParseAccept(t *testing.T) {
+	entries := parseAccept("text/plain;q=0.5, application/json;q=0.9, application/xml")
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+	if entries[0].mime != "application/xml" {
+		t.Fatalf("expected application/xml first (q=1 default), got %s", entries[0].mime)
+	}
+	if entries[1].mime != "application/json" {
+		t.Fatalf("expected application/json second, got %s", entries[1].mime)
+	}
+}
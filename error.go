@@ -0,0 +1,135 @@
+package hrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// StatusCoder can be implemented by an error to control the HTTP status
+// code the default error encoder writes.
+type StatusCoder interface {
+	HTTPStatus() int
+}
+
+// Reasoner can be implemented by an error to provide a stable, machine
+// readable code distinct from its human-readable message.
+type Reasoner interface {
+	Reason() string
+}
+
+// Error is a structured error carrying an HTTP status, a stable machine
+// code, a human message, and optional details. Handler functions can return
+// an *Error directly, or one of the sentinels below via With.
+type Error struct {
+	Code    int    // HTTP status
+	Reason  string // stable machine code, e.g. "not_found"
+	Message string
+	Details any
+	Err     error // wrapped cause, if any
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.Reason
+}
+
+// Unwrap exposes the wrapped cause, if any, to errors.Is/errors.As.
+func (e *Error) Unwrap() error { return e.Err }
+
+// HTTPStatus implements StatusCoder.
+func (e *Error) HTTPStatus() int { return e.Code }
+
+// With returns a copy of e with Message set from format/args, so a sentinel
+// can be specialized per call site: hrpc.ErrNotFound.With("user %s", id).
+func (e *Error) With(format string, args ...any) *Error {
+	ne := *e
+	ne.Message = fmt.Sprintf(format, args...)
+	return &ne
+}
+
+// WithErr returns a copy of e wrapping err as its cause.
+func (e *Error) WithErr(err error) *Error {
+	ne := *e
+	ne.Err = err
+	return &ne
+}
+
+// Sentinel errors for common HTTP failure modes. Use With/WithErr to
+// specialize them per call site rather than mutating them in place.
+var (
+	ErrBadRequest       = &Error{Code: http.StatusBadRequest, Reason: "bad_request", Message: "bad request"}
+	ErrNotFound         = &Error{Code: http.StatusNotFound, Reason: "not_found", Message: "not found"}
+	ErrUnauthenticated  = &Error{Code: http.StatusUnauthorized, Reason: "unauthenticated", Message: "unauthenticated"}
+	ErrPermissionDenied = &Error{Code: http.StatusForbidden, Reason: "permission_denied", Message: "permission denied"}
+)
+
+// validationError marks an error returned from Validatable.Valid as a 400,
+// without requiring every Valid implementation to also implement
+// StatusCoder. Construct one with ValidationError.
+type validationError struct {
+	err error
+}
+
+func (e *validationError) Error() string   { return e.err.Error() }
+func (e *validationError) Unwrap() error   { return e.err }
+func (e *validationError) HTTPStatus() int { return http.StatusBadRequest }
+
+// ValidationError wraps err so the default error encoder treats it as a 400
+// Bad Request, the same treatment Manager.Handler gives to Validatable
+// failures decoded inline.
+func ValidationError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &validationError{err}
+}
+
+type errorResponseBody struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Code    int    `json:"code"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+	Details any    `json:"details,omitempty"`
+}
+
+// DefaultErrorEncoder writes a standardized {"error": {...}} JSON body for
+// err: it derives the status, reason, message and details from an *Error in
+// err's chain; then the first error in the chain that implements StatusCoder
+// or Reasoner sets the status or reason, overriding the *Error's; with none
+// of these it responds 500 with err's message.
+func DefaultErrorEncoder(w http.ResponseWriter, r *http.Request, err error) {
+	detail := errorDetail{Code: http.StatusInternalServerError, Message: err.Error()}
+
+	var herr *Error
+	if errors.As(err, &herr) {
+		detail.Code = herr.Code
+		detail.Reason = herr.Reason
+		detail.Details = herr.Details
+		if herr.Message != "" {
+			detail.Message = herr.Message
+		}
+	}
+
+	var sc StatusCoder
+	if errors.As(err, &sc) {
+		detail.Code = sc.HTTPStatus()
+	}
+	var rs Reasoner
+	if errors.As(err, &rs) {
+		detail.Reason = rs.Reason()
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(detail.Code)
+	json.NewEncoder(w).Encode(errorResponseBody{Error: detail})
+}
@@ -0,0 +1,96 @@
+package hrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestErrorWith(t *testing.T) {
+	err := ErrNotFound.With("user %s", "42")
+	if err.Error() != "user 42" {
+		t.Fatalf("unexpected message: %q", err.Error())
+	}
+	if err.HTTPStatus() != http.StatusNotFound {
+		t.Fatalf("unexpected status: %d", err.HTTPStatus())
+	}
+	if ErrNotFound.Message != "not found" {
+		t.Fatalf("With should not mutate the sentinel, got %q", ErrNotFound.Message)
+	}
+}
+
+func TestErrorWithErrAndUnwrap(t *testing.T) {
+	cause := errors.New("boom")
+	err := ErrBadRequest.WithErr(cause)
+	if !errors.Is(err, cause) {
+		t.Fatalf("expected errors.Is to find the wrapped cause")
+	}
+}
+
+func TestDefaultErrorEncoder(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "http://localhost", nil)
+	DefaultErrorEncoder(w, r, ErrPermissionDenied.With("no access"))
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("unexpected status: %d", w.Code)
+	}
+	var body errorResponseBody
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	if body.Error.Reason != "permission_denied" {
+		t.Fatalf("unexpected reason: %q", body.Error.Reason)
+	}
+	if body.Error.Message != "no access" {
+		t.Fatalf("unexpected message: %q", body.Error.Message)
+	}
+}
+
+func TestDefaultErrorEncoderFallback(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "http://localhost", nil)
+	DefaultErrorEncoder(w, r, errors.New("unexpected"))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("unexpected status: %d", w.Code)
+	}
+}
+
+type customStatusError struct{ code int }
+
+func (e *customStatusError) Error() string   { return fmt.Sprintf("status %d", e.code) }
+func (e *customStatusError) HTTPStatus() int { return e.code }
+
+func TestDefaultErrorEncoderStatusCoder(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "http://localhost", nil)
+	DefaultErrorEncoder(w, r, &customStatusError{code: http.StatusTeapot})
+
+	if w.Code != http.StatusTeapot {
+		t.Fatalf("unexpected status: %d", w.Code)
+	}
+}
+
+func TestHandlerValidationFailureIs400(t *testing.T) {
+	m := Manager{
+		Decoder:  jsonDecoder,
+		Validate: true,
+	}
+	h := m.Handler(func(ctx context.Context, req *requestType) (any, error) {
+		return nil, nil
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "http://localhost", strings.NewReader(`{"data": -1}`))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a Validatable failure, got %d", w.Code)
+	}
+}
@@ -0,0 +1,125 @@
+package hrpc
+
+import (
+	"context"
+	"net/http"
+)
+
+// HandlerFunc builds a typed http.Handler for fn without the per-request
+// reflect.Value allocations that Manager.Handler(any) needs: Req is
+// allocated with new(Req), decoded, optionally validated, and passed to fn
+// directly. Prefer this over Manager.Handler whenever Req/Resp are known at
+// compile time; fall back to Manager.Handler(any) for dynamic cases.
+func HandlerFunc[Req, Resp any](m *Manager, fn func(ctx context.Context, req *Req) (*Resp, error), interceptors ...Interceptor) http.Handler {
+	return buildGenericHandler[Req](m, interceptors, func(ctx context.Context, req *Req) (any, error) {
+		return fn(ctx, req)
+	})
+}
+
+// HandlerFuncValue is the non-pointer-request, non-pointer-response variant
+// of HandlerFunc.
+func HandlerFuncValue[Req, Resp any](m *Manager, fn func(req Req) (Resp, error), interceptors ...Interceptor) http.Handler {
+	return buildGenericHandler[Req](m, interceptors, func(ctx context.Context, req *Req) (any, error) {
+		return fn(*req)
+	})
+}
+
+// HandlerFuncNoResponse is the variant of HandlerFunc for handlers that
+// don't return a response body.
+func HandlerFuncNoResponse[Req any](m *Manager, fn func(ctx context.Context, req *Req) error, interceptors ...Interceptor) http.Handler {
+	decoder := m.decoder()
+	errorEncoder := m.errorEncoder()
+	codecs := m.Codecs.expand()
+	run := chain(m.joinInterceptors(interceptors))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := new(Req)
+		reqDecoder := decoder
+		if len(codecs) > 0 {
+			if c, ok := codecForContentType(codecs, r); ok {
+				reqDecoder = c.Decode
+			}
+		}
+		if err := reqDecoder(r, req); err != nil {
+			errorEncoder(w, r, err)
+			return
+		}
+		if m.Validate {
+			if v, ok := any(req).(Validatable); ok {
+				if err := v.Valid(); err != nil {
+					errorEncoder(w, r, ValidationError(err))
+					return
+				}
+			}
+		}
+
+		endpoint := run(func(ctx context.Context, anyReq any) (any, error) {
+			return nil, fn(ctx, anyReq.(*Req))
+		})
+		if _, err := endpoint(r.Context(), req); err != nil {
+			errorEncoder(w, r, err)
+			return
+		}
+	})
+}
+
+// buildGenericHandler implements the decode/validate/encode shell shared by
+// HandlerFunc and HandlerFuncValue; call adapts the user function to a
+// uniform (ctx, *Req) (any, error) shape.
+func buildGenericHandler[Req any](m *Manager, interceptors []Interceptor, call func(ctx context.Context, req *Req) (any, error)) http.Handler {
+	decoder := m.decoder()
+	encoder := m.encoder()
+	errorEncoder := m.errorEncoder()
+	codecs := m.Codecs.expand()
+	run := chain(m.joinInterceptors(interceptors))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respEncoder := encoder
+		if len(codecs) > 0 {
+			if mt, c, ok := codecForAccept(codecs, r, m.DefaultMime); ok {
+				w.Header().Set("Content-Type", mt)
+				respEncoder = c.Encode
+			}
+		}
+
+		req := new(Req)
+		reqDecoder := decoder
+		if len(codecs) > 0 {
+			if c, ok := codecForContentType(codecs, r); ok {
+				reqDecoder = c.Decode
+			}
+		}
+		if err := reqDecoder(r, req); err != nil {
+			errorEncoder(w, r, err)
+			return
+		}
+		if m.Validate {
+			if v, ok := any(req).(Validatable); ok {
+				if err := v.Valid(); err != nil {
+					errorEncoder(w, r, ValidationError(err))
+					return
+				}
+			}
+		}
+
+		endpoint := run(func(ctx context.Context, anyReq any) (any, error) {
+			return call(ctx, anyReq.(*Req))
+		})
+
+		res, err := endpoint(r.Context(), req)
+		if err != nil {
+			errorEncoder(w, r, err)
+			return
+		}
+		respEncoder(w, r, res)
+	})
+}
+
+// joinInterceptors combines m.Interceptors with per-handler interceptors, in
+// the same order Manager.Handler uses.
+func (m *Manager) joinInterceptors(interceptors []Interceptor) []Interceptor {
+	all := make([]Interceptor, 0, len(m.Interceptors)+len(interceptors))
+	all = append(all, m.Interceptors...)
+	all = append(all, interceptors...)
+	return all
+}
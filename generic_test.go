@@ -0,0 +1,89 @@
+package hrpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandlerFunc(t *testing.T) {
+	var callSuccess, callError bool
+	m := Manager{
+		Decoder: jsonDecoder,
+		Encoder: func(w http.ResponseWriter, r *http.Request, res any) {
+			callSuccess = true
+		},
+		ErrorEncoder: func(w http.ResponseWriter, r *http.Request, err error) {
+			callError = true
+		},
+		Validate: true,
+	}
+
+	h := HandlerFunc(&m, func(ctx context.Context, req *requestType) (*requestType, error) {
+		return req, nil
+	})
+
+	reset := func() { callSuccess, callError = false, false }
+
+	reset()
+	r := httptest.NewRequest(http.MethodPost, "http://localhost", strings.NewReader(`{"data": 1}`))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if !callSuccess || callError {
+		t.Fatalf("expected success, got success=%v error=%v", callSuccess, callError)
+	}
+
+	reset()
+	r = httptest.NewRequest(http.MethodPost, "http://localhost", strings.NewReader(`{"data": -1}`))
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if callSuccess || !callError {
+		t.Fatalf("expected validation error, got success=%v error=%v", callSuccess, callError)
+	}
+}
+
+func TestHandlerFuncValue(t *testing.T) {
+	m := Manager{
+		Decoder: jsonDecoder,
+		Encoder: func(w http.ResponseWriter, r *http.Request, res any) {
+			if res.(int) != 2 {
+				t.Fatalf("unexpected response: %v", res)
+			}
+		},
+	}
+
+	h := HandlerFuncValue(&m, func(req requestType) (int, error) {
+		return req.Data * 2, nil
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "http://localhost", strings.NewReader(`{"data": 1}`))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+}
+
+func TestHandlerFuncNoResponse(t *testing.T) {
+	var gotErr error
+	m := Manager{
+		Decoder: jsonDecoder,
+		ErrorEncoder: func(w http.ResponseWriter, r *http.Request, err error) {
+			gotErr = err
+		},
+	}
+
+	h := HandlerFuncNoResponse(&m, func(ctx context.Context, req *requestType) error {
+		if req.Data != 1 {
+			return errors.New("bad data")
+		}
+		return nil
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "http://localhost", strings.NewReader(`{"data": 2}`))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if gotErr == nil {
+		t.Fatalf("expected error")
+	}
+}
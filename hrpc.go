@@ -1,6 +1,7 @@
 package hrpc
 
 import (
+	"context"
 	"net/http"
 	"reflect"
 )
@@ -20,6 +21,21 @@ type Manager struct {
 	Encoder      Encoder
 	ErrorEncoder ErrorEncoder
 	Validate     bool // set to true to validate request after decode using Validatable interface
+
+	// Codecs is a MIME-keyed registry used for content negotiation.
+	// When set, it takes precedence over Decoder/Encoder: the decoder is
+	// selected from the request's Content-Type and the encoder from its
+	// Accept header (quality factors honored). Decoder/Encoder remain as
+	// the fallback when Codecs is nil or a request's type isn't found.
+	Codecs Codecs
+
+	// DefaultMime is the MIME type used to pick the encoder when Accept is
+	// empty, "*/*", or matches nothing in Codecs.
+	DefaultMime string
+
+	// Interceptors run around every handler built from this Manager, in
+	// addition to any passed directly to Handler. See Use.
+	Interceptors []Interceptor
 }
 
 func (m *Manager) decoder() Decoder {
@@ -38,7 +54,7 @@ func (m *Manager) encoder() Encoder {
 
 func (m *Manager) errorEncoder() ErrorEncoder {
 	if m.ErrorEncoder == nil {
-		return func(http.ResponseWriter, *http.Request, error) {}
+		return DefaultErrorEncoder
 	}
 	return m.ErrorEncoder
 }
@@ -79,7 +95,10 @@ func setOrPanic(m map[mapIndex]int, k mapIndex, v int) {
 // second input can be anything which will pass to RequestDecoder function.
 // first output must be the result which will pass to success handler.
 // second output must be an error interface which will pass to error handler if not nil.
-func (m *Manager) Handler(f any) http.Handler {
+//
+// interceptors wrap the decoded-request call in addition to any registered
+// with Manager.Use; Manager-level interceptors run outermost.
+func (m *Manager) Handler(f any, interceptors ...Interceptor) http.Handler {
 	fv := reflect.ValueOf(f)
 	ft := fv.Type()
 	if ft.Kind() != reflect.Func {
@@ -137,6 +156,11 @@ func (m *Manager) Handler(f any) http.Handler {
 	encoder := m.encoder()
 	decoder := m.decoder()
 	errorEncoder := m.errorEncoder()
+	codecs := m.Codecs.expand()
+
+	run := chain(m.joinInterceptors(interceptors))
+
+	_, hasResp := mapOut[miAny]
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		vIn := make([]reflect.Value, numIn)
@@ -144,11 +168,29 @@ func (m *Manager) Handler(f any) http.Handler {
 		if i, ok := mapIn[miContext]; ok {
 			vIn[i] = reflect.ValueOf(r.Context())
 		}
-		// inject request interface
+
+		// resolve encoder by Accept before calling f so f's return value
+		// can be written with the negotiated codec
+		respEncoder := encoder
+		if len(codecs) > 0 {
+			if mt, c, ok := codecForAccept(codecs, r, m.DefaultMime); ok {
+				w.Header().Set("Content-Type", mt)
+				respEncoder = c.Encode
+			}
+		}
+
+		// decode and validate the request interface, if any
+		var decodedReq any
 		if i, ok := mapIn[miAny]; ok {
 			rfReq := reflect.New(infType)
 			req := rfReq.Interface()
-			err := decoder(r, req)
+			reqDecoder := decoder
+			if len(codecs) > 0 {
+				if c, ok := codecForContentType(codecs, r); ok {
+					reqDecoder = c.Decode
+				}
+			}
+			err := reqDecoder(r, req)
 			if err != nil {
 				errorEncoder(w, r, err)
 				return
@@ -158,15 +200,17 @@ func (m *Manager) Handler(f any) http.Handler {
 				if req, ok := req.(Validatable); ok {
 					err = req.Valid()
 					if err != nil {
-						errorEncoder(w, r, err)
+						errorEncoder(w, r, ValidationError(err))
 						return
 					}
 				}
 			}
 			if infPtr {
 				vIn[i] = rfReq
+				decodedReq = req
 			} else {
 				vIn[i] = rfReq.Elem()
+				decodedReq = rfReq.Elem().Interface()
 			}
 		}
 		// inject request
@@ -178,19 +222,38 @@ func (m *Manager) Handler(f any) http.Handler {
 			vIn[i] = reflect.ValueOf(w)
 		}
 
-		vOut := fv.Call(vIn)
-		// check error
-		if i, ok := mapOut[miError]; ok {
-			if vErr := vOut[i]; !vErr.IsNil() {
-				if err, ok := vErr.Interface().(error); ok && err != nil {
-					errorEncoder(w, r, err)
-					return
+		// the innermost endpoint invokes f, reusing vIn for the fixed
+		// (request/response writer) slots and overwriting the context and
+		// decoded-request slots, since interceptors may replace ctx and req
+		endpoint := run(func(ctx context.Context, req any) (any, error) {
+			if i, ok := mapIn[miContext]; ok {
+				vIn[i] = reflect.ValueOf(ctx)
+			}
+			if i, ok := mapIn[miAny]; ok {
+				vIn[i] = reflect.ValueOf(req)
+			}
+
+			vOut := fv.Call(vIn)
+			if i, ok := mapOut[miError]; ok {
+				if vErr := vOut[i]; !vErr.IsNil() {
+					if err, ok := vErr.Interface().(error); ok && err != nil {
+						return nil, err
+					}
 				}
 			}
+			if hasResp {
+				return vOut[mapOut[miAny]].Interface(), nil
+			}
+			return nil, nil
+		})
+
+		res, err := endpoint(r.Context(), decodedReq)
+		if err != nil {
+			errorEncoder(w, r, err)
+			return
 		}
-		// check response
-		if i, ok := mapOut[miAny]; ok {
-			encoder(w, r, vOut[i].Interface())
+		if hasResp {
+			respEncoder(w, r, res)
 		}
 
 		// if f is not return response, it may already call from native response writer
@@ -0,0 +1,32 @@
+package hrpc
+
+import "context"
+
+// Endpoint is a decoded-request handler: it receives the context and the
+// request value already decoded (and validated, if Manager.Validate is set)
+// by Handler, and returns the response value or an error.
+type Endpoint func(ctx context.Context, req any) (any, error)
+
+// Interceptor wraps an Endpoint with cross-cutting behavior such as
+// logging, metrics, auth, rate limiting, recovery, or tracing. Interceptors
+// run at the semantic layer, after decoding and before the handler function
+// is invoked.
+type Interceptor func(next Endpoint) Endpoint
+
+// chain composes interceptors into a single Interceptor. The first
+// interceptor in the slice runs outermost, i.e. it sees the request before
+// the rest of the chain and the response after it.
+func chain(interceptors []Interceptor) Interceptor {
+	return func(next Endpoint) Endpoint {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			next = interceptors[i](next)
+		}
+		return next
+	}
+}
+
+// Use appends interceptors that apply to every handler built from m.
+// Interceptors registered with Use run before any passed to Handler itself.
+func (m *Manager) Use(interceptors ...Interceptor) {
+	m.Interceptors = append(m.Interceptors, interceptors...)
+}
@@ -0,0 +1,55 @@
+// Package interceptor provides built-in hrpc.Interceptor implementations for
+// common cross-cutting concerns: panic recovery, timeouts, and request
+// validation.
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/acoshift/hrpc"
+)
+
+// RecoverInterceptor converts panics raised by the wrapped endpoint into
+// errors instead of letting them crash the server.
+func RecoverInterceptor() hrpc.Interceptor {
+	return func(next hrpc.Endpoint) hrpc.Endpoint {
+		return func(ctx context.Context, req any) (res any, err error) {
+			defer func() {
+				if p := recover(); p != nil {
+					err = fmt.Errorf("hrpc: recovered from panic: %v", p)
+				}
+			}()
+			return next(ctx, req)
+		}
+	}
+}
+
+// TimeoutInterceptor bounds the wrapped endpoint's context to d.
+func TimeoutInterceptor(d time.Duration) hrpc.Interceptor {
+	return func(next hrpc.Endpoint) hrpc.Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+			return next(ctx, req)
+		}
+	}
+}
+
+// ValidateInterceptor calls Valid on req if it implements hrpc.Validatable,
+// returning its error instead of invoking the wrapped endpoint. This is the
+// same check Manager performs inline when Validate is set, extracted so it
+// can be composed explicitly into an interceptor chain.
+func ValidateInterceptor() hrpc.Interceptor {
+	return func(next hrpc.Endpoint) hrpc.Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+			if v, ok := req.(hrpc.Validatable); ok {
+				if err := v.Valid(); err != nil {
+					return nil, hrpc.ValidationError(err)
+				}
+			}
+			return next(ctx, req)
+		}
+	}
+}
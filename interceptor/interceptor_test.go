@@ -0,0 +1,67 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/acoshift/hrpc"
+)
+
+func TestRecoverInterceptor(t *testing.T) {
+	ep := RecoverInterceptor()(func(ctx context.Context, req any) (any, error) {
+		panic("boom")
+	})
+	_, err := ep(context.Background(), nil)
+	if err == nil {
+		t.Fatalf("expected error from recovered panic")
+	}
+}
+
+func TestTimeoutInterceptor(t *testing.T) {
+	ep := TimeoutInterceptor(time.Millisecond)(func(ctx context.Context, req any) (any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	_, err := ep(context.Background(), nil)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+type validatableReq struct{ ok bool }
+
+func (r validatableReq) Valid() error {
+	if !r.ok {
+		return errors.New("invalid")
+	}
+	return nil
+}
+
+func TestValidateInterceptor(t *testing.T) {
+	var called bool
+	ep := ValidateInterceptor()(func(ctx context.Context, req any) (any, error) {
+		called = true
+		return nil, nil
+	})
+
+	_, err := ep(context.Background(), validatableReq{ok: false})
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	if called {
+		t.Fatalf("next should not be called when validation fails")
+	}
+
+	called = false
+	_, err = ep(context.Background(), validatableReq{ok: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !called {
+		t.Fatalf("next should be called when validation passes")
+	}
+}
+
+var _ hrpc.Validatable = validatableReq{}
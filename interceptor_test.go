@@ -0,0 +1,82 @@
+package hrpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestManagerUse(t *testing.T) {
+	var order []string
+
+	mark := func(name string) Interceptor {
+		return func(next Endpoint) Endpoint {
+			return func(ctx context.Context, req any) (any, error) {
+				order = append(order, name)
+				return next(ctx, req)
+			}
+		}
+	}
+
+	m := Manager{
+		Decoder: jsonDecoder,
+		Encoder: func(w http.ResponseWriter, r *http.Request, res any) {},
+	}
+	m.Use(mark("manager"))
+
+	h := m.Handler(func(ctx context.Context, req *requestType) (any, error) {
+		order = append(order, "handler")
+		return nil, nil
+	}, mark("per-handler"))
+
+	r := httptest.NewRequest(http.MethodPost, "http://localhost", strings.NewReader("{\"data\": 1}"))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	want := []string{"manager", "per-handler", "handler"}
+	if len(order) != len(want) {
+		t.Fatalf("unexpected call order: %v", order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("unexpected call order: %v", order)
+		}
+	}
+}
+
+func TestInterceptorShortCircuit(t *testing.T) {
+	var calledHandler bool
+	errStop := errors.New("stop")
+
+	denyAll := func(next Endpoint) Endpoint {
+		return func(ctx context.Context, req any) (any, error) {
+			return nil, errStop
+		}
+	}
+
+	var gotErr error
+	m := Manager{
+		Decoder: jsonDecoder,
+		ErrorEncoder: func(w http.ResponseWriter, r *http.Request, err error) {
+			gotErr = err
+		},
+	}
+	h := m.Handler(func(ctx context.Context, req *requestType) (any, error) {
+		calledHandler = true
+		return nil, nil
+	}, denyAll)
+
+	r := httptest.NewRequest(http.MethodPost, "http://localhost", strings.NewReader("{\"data\": 1}"))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if calledHandler {
+		t.Fatalf("handler should not be called when an interceptor short-circuits")
+	}
+	if gotErr != errStop {
+		t.Fatalf("expected errStop, got %v", gotErr)
+	}
+}
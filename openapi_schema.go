@@ -0,0 +1,123 @@
+package hrpc
+
+import (
+	"reflect"
+	"strings"
+)
+
+// OpenAPIEnum can be implemented by a request/response field type to list
+// its allowed values in the generated schema.
+type OpenAPIEnum interface {
+	OpenAPIEnum() []any
+}
+
+// OpenAPIDoc can be implemented by a request/response field type to provide
+// a description for the generated schema.
+type OpenAPIDoc interface {
+	OpenAPIDoc() string
+}
+
+// jsonSchema is a minimal JSON Schema subset, enough to describe the
+// request/response structs hrpc handlers accept.
+type jsonSchema struct {
+	Type        string                 `json:"type,omitempty"`
+	Format      string                 `json:"format,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Properties  map[string]*jsonSchema `json:"properties,omitempty"`
+	Items       *jsonSchema            `json:"items,omitempty"`
+	Required    []string               `json:"required,omitempty"`
+	Enum        []any                  `json:"enum,omitempty"`
+}
+
+// schemaForType derives a jsonSchema from a Go type, unwrapping pointers,
+// honoring OpenAPIEnum/OpenAPIDoc, and recursing into structs, slices, and
+// arrays. A map becomes a plain "object" schema; its value type is not
+// described.
+func schemaForType(t reflect.Type) *jsonSchema {
+	return schemaForTypeVisit(t, map[reflect.Type]bool{})
+}
+
+// schemaForTypeVisit is schemaForType's recursive worker. seen tracks the
+// struct types on the current path from the root, not every type ever
+// visited, so a type reused by unrelated sibling fields still expands
+// fully; only an actual cycle (the type is its own ancestor) is cut short.
+func schemaForTypeVisit(t reflect.Type, seen map[reflect.Type]bool) *jsonSchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	s := &jsonSchema{}
+	if enum, ok := reflect.New(t).Interface().(OpenAPIEnum); ok {
+		s.Enum = enum.OpenAPIEnum()
+	}
+	if doc, ok := reflect.New(t).Interface().(OpenAPIDoc); ok {
+		s.Description = doc.OpenAPIDoc()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		s.Type = "object"
+		if seen[t] {
+			// recursive type: stop expanding instead of recursing forever
+			break
+		}
+		seen[t] = true
+		schemaForStructInto(s, t, seen)
+		delete(seen, t)
+	case reflect.Slice, reflect.Array:
+		s.Type = "array"
+		s.Items = schemaForTypeVisit(t.Elem(), seen)
+	case reflect.Map:
+		s.Type = "object"
+	case reflect.String:
+		s.Type = "string"
+	case reflect.Bool:
+		s.Type = "boolean"
+	case reflect.Float32, reflect.Float64:
+		s.Type = "number"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s.Type = "integer"
+	}
+	return s
+}
+
+// schemaForStructInto populates an object schema's properties and required
+// list from t's exported fields, honoring json tags, omitempty, pointer as
+// optional, and embedded structs.
+func schemaForStructInto(s *jsonSchema, t reflect.Type, seen map[reflect.Type]bool) {
+	s.Properties = map[string]*jsonSchema{}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue // unexported
+		}
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if name == "" && f.Anonymous {
+			embedded := schemaForTypeVisit(f.Type, seen)
+			for k, v := range embedded.Properties {
+				s.Properties[k] = v
+			}
+			s.Required = append(s.Required, embedded.Required...)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		fieldSchema := schemaForTypeVisit(f.Type, seen)
+		s.Properties[name] = fieldSchema
+
+		optional := f.Type.Kind() == reflect.Ptr || strings.Contains(opts, "omitempty")
+		if !optional {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package hrpc
+
+import (
+	"reflect"
+	"testing"
+)
+
+type status int
+
+func (status) OpenAPIEnum() []any { return []any{0, 1, 2} }
+
+type address struct {
+	City string `json:"city"`
+}
+
+type profile struct {
+	address
+	Name   string   `json:"name"`
+	Status status   `json:"status"`
+	Tags   []string `json:"tags,omitempty"`
+}
+
+func (profile) OpenAPIDoc() string { return "a user profile" }
+
+func TestSchemaForType(t *testing.T) {
+	s := schemaForType(reflect.TypeOf(profile{}))
+	if s.Type != "object" {
+		t.Fatalf("expected object schema, got %q", s.Type)
+	}
+	if s.Description != "a user profile" {
+		t.Fatalf("expected OpenAPIDoc description, got %q", s.Description)
+	}
+	if _, ok := s.Properties["city"]; !ok {
+		t.Fatalf("expected embedded struct field city to be promoted, got %v", s.Properties)
+	}
+	if _, ok := s.Properties["name"]; !ok {
+		t.Fatalf("expected name property, got %v", s.Properties)
+	}
+	if len(s.Properties["status"].Enum) != 3 {
+		t.Fatalf("expected enum with 3 values, got %v", s.Properties["status"].Enum)
+	}
+
+	requireContains := func(name string) {
+		for _, r := range s.Required {
+			if r == name {
+				return
+			}
+		}
+		t.Fatalf("expected %q to be required, got %v", name, s.Required)
+	}
+	requireContains("name")
+	requireContains("city")
+
+	for _, r := range s.Required {
+		if r == "tags" {
+			t.Fatalf("omitempty field should not be required")
+		}
+	}
+}
+
+// node is an ordinary self-referential tree shape: any real API with a
+// comment thread, a category tree, or a linked list will have one.
+type node struct {
+	Name     string  `json:"name"`
+	Children []*node `json:"children,omitempty"`
+}
+
+func TestSchemaForTypeRecursive(t *testing.T) {
+	s := schemaForType(reflect.TypeOf(node{}))
+	if s.Type != "object" {
+		t.Fatalf("expected object schema, got %q", s.Type)
+	}
+
+	children, ok := s.Properties["children"]
+	if !ok {
+		t.Fatalf("expected children property, got %v", s.Properties)
+	}
+	if children.Type != "array" {
+		t.Fatalf("expected array schema for children, got %q", children.Type)
+	}
+	if children.Items.Type != "object" {
+		t.Fatalf("expected object schema for children items, got %q", children.Items.Type)
+	}
+	// the cycle is cut off: the nested node's own children field isn't expanded
+	if _, ok := children.Items.Properties["children"]; ok {
+		t.Fatalf("expected cycle to stop recursion instead of expanding forever")
+	}
+}
@@ -0,0 +1,201 @@
+package hrpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// Router records each (path, fn) handler registration and can emit an
+// OpenAPI 3.0 document describing them, so API consumers get machine
+// readable docs without a separate IDL.
+type Router struct {
+	Manager *Manager
+
+	Title       string // OpenAPI info.title, defaults to "hrpc"
+	Version     string // OpenAPI info.version, defaults to "0.0.0"
+	Description string // OpenAPI info.description
+
+	mux    *http.ServeMux
+	routes []routeEntry
+}
+
+type routeEntry struct {
+	path     string
+	reqType  reflect.Type
+	respType reflect.Type
+}
+
+func (rt *Router) manager() *Manager {
+	if rt.Manager == nil {
+		return &Manager{}
+	}
+	return rt.Manager
+}
+
+// Handle mounts fn at path using Router's Manager and records its request
+// and response types for ServeOpenAPI.
+func (rt *Router) Handle(path string, fn any, interceptors ...Interceptor) http.Handler {
+	h := rt.manager().Handler(fn, interceptors...)
+
+	if rt.mux == nil {
+		rt.mux = http.NewServeMux()
+	}
+	rt.mux.Handle(path, h)
+
+	reqType, respType := signatureTypes(fn)
+	rt.routes = append(rt.routes, routeEntry{path: path, reqType: reqType, respType: respType})
+	return h
+}
+
+// ServeHTTP dispatches to the handlers registered with Handle.
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if rt.mux == nil {
+		http.NotFound(w, r)
+		return
+	}
+	rt.mux.ServeHTTP(w, r)
+}
+
+// ServeOpenAPI writes the OpenAPI 3.0 document for every handler registered
+// with Handle, as JSON or, if the request's Accept header asks for it, YAML.
+func (rt *Router) ServeOpenAPI(w http.ResponseWriter, r *http.Request) {
+	doc := rt.document()
+	if acceptsYAML(r.Header.Get("Accept")) {
+		w.Header().Set("Content-Type", "application/yaml; charset=utf-8")
+		encodeYAML(w, doc)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(doc)
+}
+
+// acceptsYAML reports whether the highest quality-factor Accept entry that
+// matches either representation hrpc serves this document in asks for
+// YAML, using the same quality-aware negotiation codecForAccept uses.
+func acceptsYAML(accept string) bool {
+	for _, e := range parseAccept(accept) {
+		switch {
+		case strings.Contains(e.mime, "yaml"):
+			return true
+		case e.mime == "application/json", e.mime == "*/*":
+			return false
+		}
+	}
+	return false
+}
+
+// signatureTypes returns the request and response struct types of a handler
+// function, ignoring context.Context, *http.Request, http.ResponseWriter,
+// error, and a trailing grpc-style options slice.
+func signatureTypes(f any) (reqType, respType reflect.Type) {
+	ft := reflect.TypeOf(f)
+	if ft == nil || ft.Kind() != reflect.Func {
+		panic("hrpc: f must be a function")
+	}
+
+	numIn := ft.NumIn()
+	for i := 0; i < numIn; i++ {
+		fi := ft.In(i)
+		if fi.Kind() == reflect.Slice && i == numIn-1 {
+			continue
+		}
+		switch fi.String() {
+		case strContext, strRequest, strResponseWriter:
+			continue
+		default:
+			reqType = fi
+		}
+	}
+
+	for i := 0; i < ft.NumOut(); i++ {
+		fo := ft.Out(i)
+		if fo.String() == strError {
+			continue
+		}
+		respType = fo
+	}
+	return
+}
+
+type openAPIDocument struct {
+	OpenAPI string                     `json:"openapi"`
+	Info    openAPIInfo                `json:"info"`
+	Paths   map[string]openAPIPathItem `json:"paths"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type openAPIPathItem struct {
+	Post openAPIOperation `json:"post"`
+}
+
+type openAPIOperation struct {
+	RequestBody *openAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+}
+
+type openAPIRequestBody struct {
+	Content map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIMediaType struct {
+	Schema *jsonSchema `json:"schema"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+func (rt *Router) document() *openAPIDocument {
+	title := rt.Title
+	if title == "" {
+		title = "hrpc"
+	}
+	version := rt.Version
+	if version == "" {
+		version = "0.0.0"
+	}
+
+	doc := &openAPIDocument{
+		OpenAPI: "3.0.3",
+		Info: openAPIInfo{
+			Title:       title,
+			Version:     version,
+			Description: rt.Description,
+		},
+		Paths: map[string]openAPIPathItem{},
+	}
+
+	for _, route := range rt.routes {
+		op := openAPIOperation{
+			Responses: map[string]openAPIResponse{
+				"200": {Description: "OK"},
+			},
+		}
+		if route.reqType != nil {
+			op.RequestBody = &openAPIRequestBody{
+				Content: map[string]openAPIMediaType{
+					"application/json": {Schema: schemaForType(route.reqType)},
+				},
+			}
+		}
+		if route.respType != nil {
+			op.Responses["200"] = openAPIResponse{
+				Description: "OK",
+				Content: map[string]openAPIMediaType{
+					"application/json": {Schema: schemaForType(route.respType)},
+				},
+			}
+		}
+		doc.Paths[route.path] = openAPIPathItem{Post: op}
+	}
+
+	return doc
+}
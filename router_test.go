@@ -0,0 +1,127 @@
+package hrpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type getUserReq struct {
+	ID string `json:"id"`
+}
+
+type getUserResp struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Nickname *string `json:"nickname,omitempty"`
+}
+
+func TestRouterServeOpenAPI(t *testing.T) {
+	m := Manager{
+		Decoder: jsonDecoder,
+		Encoder: func(w http.ResponseWriter, r *http.Request, res any) {
+			json.NewEncoder(w).Encode(res)
+		},
+	}
+
+	var router Router
+	router.Title = "user service"
+	router.Manager = &m
+	router.Handle("/user.get", func(ctx context.Context, req *getUserReq) (*getUserResp, error) {
+		return &getUserResp{ID: req.ID, Name: "User " + req.ID}, nil
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "http://localhost/openapi.json", nil)
+	w := httptest.NewRecorder()
+	router.ServeOpenAPI(w, r)
+
+	var doc openAPIDocument
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON document: %v", err)
+	}
+	if doc.Info.Title != "user service" {
+		t.Fatalf("unexpected title: %s", doc.Info.Title)
+	}
+	path, ok := doc.Paths["/user.get"]
+	if !ok {
+		t.Fatalf("expected /user.get to be registered, got %v", doc.Paths)
+	}
+	if path.Post.RequestBody == nil {
+		t.Fatalf("expected a request body schema")
+	}
+	reqSchema := path.Post.RequestBody.Content["application/json"].Schema
+	if reqSchema.Type != "object" {
+		t.Fatalf("expected object schema, got %q", reqSchema.Type)
+	}
+	if _, ok := reqSchema.Properties["id"]; !ok {
+		t.Fatalf("expected id property, got %v", reqSchema.Properties)
+	}
+
+	respSchema := path.Post.Responses["200"].Content["application/json"].Schema
+	if _, ok := respSchema.Properties["nickname"]; !ok {
+		t.Fatalf("expected nickname property, got %v", respSchema.Properties)
+	}
+	for _, req := range respSchema.Required {
+		if req == "nickname" {
+			t.Fatalf("optional pointer field should not be required")
+		}
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "http://localhost/openapi.yaml", nil)
+	r.Header.Set("Accept", "application/yaml")
+	w = httptest.NewRecorder()
+	router.ServeOpenAPI(w, r)
+	if ct := w.Header().Get("Content-Type"); !strings.Contains(ct, "yaml") {
+		t.Fatalf("expected yaml content type, got %q", ct)
+	}
+	if !strings.Contains(w.Body.String(), "openapi:") {
+		t.Fatalf("expected yaml body to contain openapi key, got %q", w.Body.String())
+	}
+}
+
+func TestAcceptsYAML(t *testing.T) {
+	cases := []struct {
+		accept string
+		want   bool
+	}{
+		{"", false},
+		{"application/yaml", true},
+		{"application/json", false},
+		// client prefers JSON (q=1) over YAML (q=0.1): must not match on
+		// substring alone
+		{"application/json;q=1, application/yaml;q=0.1", false},
+		{"application/yaml;q=1, application/json;q=0.1", true},
+		{"text/html, application/yaml;q=0.9", true},
+	}
+	for _, c := range cases {
+		if got := acceptsYAML(c.accept); got != c.want {
+			t.Errorf("acceptsYAML(%q) = %v, want %v", c.accept, got, c.want)
+		}
+	}
+}
+
+func TestRouterDispatches(t *testing.T) {
+	var callSuccess bool
+	m := Manager{
+		Decoder: jsonDecoder,
+		Encoder: func(w http.ResponseWriter, r *http.Request, res any) {
+			callSuccess = true
+		},
+	}
+
+	var router Router
+	router.Manager = &m
+	router.Handle("/user.get", func(ctx context.Context, req *getUserReq) (*getUserResp, error) {
+		return &getUserResp{ID: req.ID}, nil
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "http://localhost/user.get", strings.NewReader(`{"id":"1"}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	if !callSuccess {
+		t.Fatalf("expected handler to be dispatched")
+	}
+}
@@ -0,0 +1,147 @@
+package hrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// encodeYAML writes v as YAML by round-tripping it through JSON (so struct
+// tags are honored the same way as the JSON encoder) and walking the
+// resulting generic value. It supports the maps, slices, and scalars that
+// make up an OpenAPI document; it is not a general-purpose YAML encoder.
+func encodeYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return err
+	}
+	return writeYAMLValue(w, generic, 0)
+}
+
+func writeYAMLValue(w io.Writer, v any, indent int) error {
+	prefix := strings.Repeat("  ", indent)
+	switch val := v.(type) {
+	case map[string]any:
+		if len(val) == 0 {
+			_, err := fmt.Fprint(w, "{}\n")
+			return err
+		}
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			child := val[k]
+			if isYAMLContainer(child) && !isEmptyYAML(child) {
+				if _, err := fmt.Fprintf(w, "%s%s:\n", prefix, yamlScalar(k)); err != nil {
+					return err
+				}
+				if err := writeYAMLValue(w, child, indent+1); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "%s%s: %s\n", prefix, yamlScalar(k), yamlLeaf(child)); err != nil {
+				return err
+			}
+		}
+	case []any:
+		if len(val) == 0 {
+			_, err := fmt.Fprint(w, "[]\n")
+			return err
+		}
+		for _, item := range val {
+			if isYAMLContainer(item) && !isEmptyYAML(item) {
+				if _, err := fmt.Fprintf(w, "%s-\n", prefix); err != nil {
+					return err
+				}
+				if err := writeYAMLValue(w, item, indent+1); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "%s- %s\n", prefix, yamlLeaf(item)); err != nil {
+				return err
+			}
+		}
+	default:
+		_, err := fmt.Fprintf(w, "%s%s\n", prefix, yamlLeaf(val))
+		return err
+	}
+	return nil
+}
+
+func isYAMLContainer(v any) bool {
+	switch v.(type) {
+	case map[string]any, []any:
+		return true
+	default:
+		return false
+	}
+}
+
+func isEmptyYAML(v any) bool {
+	switch val := v.(type) {
+	case map[string]any:
+		return len(val) == 0
+	case []any:
+		return len(val) == 0
+	default:
+		return false
+	}
+}
+
+// yamlLeaf renders a scalar, or the flow-style literal for an empty
+// map/slice.
+func yamlLeaf(v any) string {
+	switch val := v.(type) {
+	case map[string]any:
+		return "{}"
+	case []any:
+		return "[]"
+	case nil:
+		return "null"
+	default:
+		return yamlScalar(val)
+	}
+}
+
+func yamlScalar(v any) string {
+	switch val := v.(type) {
+	case string:
+		if val == "" || needsYAMLQuote(val) {
+			return strconv.Quote(val)
+		}
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+func needsYAMLQuote(s string) bool {
+	switch s {
+	case "true", "false", "null", "~":
+		return true
+	}
+	for _, c := range s {
+		switch c {
+		case ':', '#', '-', '[', ']', '{', '}', '"', '\'', '\n':
+			return true
+		}
+	}
+	return false
+}